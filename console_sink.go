@@ -0,0 +1,78 @@
+package lopher
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	ansiCyan  = "\x1b[36m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// ConsoleSink writes entries to Writer, colorizing the whole line by level
+// when Writer is a terminal.
+type ConsoleSink struct {
+	// Writer is the destination; defaults to os.Stderr if nil.
+	Writer io.Writer
+	// MinLevel is the lowest level this sink accepts; the zero value accepts everything.
+	MinLevel string
+	// Flags controls the rendered header, same meaning as Log.Flags.
+	Flags LogFlags
+}
+
+// NewConsoleSink creates a ConsoleSink writing to w (os.Stderr if w is nil).
+func NewConsoleSink(w io.Writer, minLevel string, flags LogFlags) *ConsoleSink {
+	if w == nil {
+		w = os.Stderr
+	}
+	return &ConsoleSink{Writer: w, MinLevel: minLevel, Flags: flags}
+}
+
+// Accept implements Sink.
+func (s *ConsoleSink) Accept(e Entry) {
+	if !levelAtLeast(e.Level, s.MinLevel) {
+		return
+	}
+
+	w := s.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+
+	header := fmtHeader(s.Flags, e.Caller, e.Time)
+	line := fmt.Sprintf("%s[%s] %s%s\n", header, e.Level, collapseMessage(e.Message), formatFields(e.Fields))
+
+	if isTerminal(w) {
+		io.WriteString(w, color(e.Level)+line+ansiReset)
+		return
+	}
+
+	io.WriteString(w, line)
+}
+
+// Close implements Sink. ConsoleSink holds nothing that needs releasing.
+func (s *ConsoleSink) Close() error { return nil }
+
+func color(level string) string {
+	if level == "DEBUG" {
+		return ansiCyan
+	}
+	return ansiGreen
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
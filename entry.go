@@ -0,0 +1,20 @@
+package lopher
+
+import "time"
+
+// Entry is a single structured log record produced by a Logger. Sinks
+// receive one Entry per Info/Debug call and decide for themselves how (and
+// whether) to render it.
+type Entry struct {
+	// Time is when the entry was logged.
+	Time time.Time
+	// Level is "INFO" or "DEBUG".
+	Level string
+	// Caller is the full "/path/to/file.go:23", or empty if no caller flag
+	// was set.
+	Caller string
+	// Message is the already-formatted log message, newlines included.
+	Message string
+	// Fields are the key/value pairs inherited from With, in call order.
+	Fields []interface{}
+}
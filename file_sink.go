@@ -0,0 +1,111 @@
+package lopher
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSink writes entries to a file on disk, rotating it once it exceeds
+// MaxSize bytes or has been open longer than MaxAge. A rotated-out file is
+// renamed to "<path>.<timestamp>"; set MaxSize or MaxAge to 0 to disable
+// that rotation trigger.
+type FileSink struct {
+	Path     string
+	MaxSize  int64
+	MaxAge   time.Duration
+	MinLevel string
+	Flags    LogFlags
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewFileSink opens (creating if needed) path and returns a FileSink that
+// appends to it, rotating per maxSize/maxAge.
+func NewFileSink(path string, maxSize int64, maxAge time.Duration, minLevel string, flags LogFlags) (*FileSink, error) {
+	s := &FileSink{Path: path, MaxSize: maxSize, MaxAge: maxAge, MinLevel: minLevel, Flags: flags}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+	s.opened = time.Now()
+	return nil
+}
+
+// Accept implements Sink.
+func (s *FileSink) Accept(e Entry) {
+	if !levelAtLeast(e.Level, s.MinLevel) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shouldRotate() {
+		if err := s.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "lopher: could not rotate %s: %+v\n", s.Path, err)
+			return
+		}
+	}
+
+	header := fmtHeader(s.Flags, e.Caller, e.Time)
+	n, err := fmt.Fprintf(s.file, "%s[%s] %s%s\n", header, e.Level, collapseMessage(e.Message), formatFields(e.Fields))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lopher: could not write to %s: %+v\n", s.Path, err)
+		return
+	}
+
+	s.size += int64(n)
+}
+
+func (s *FileSink) shouldRotate() bool {
+	if s.MaxSize > 0 && s.size >= s.MaxSize {
+		return true
+	}
+	return s.MaxAge > 0 && time.Since(s.opened) >= s.MaxAge
+}
+
+func (s *FileSink) rotate() error {
+	if s.file != nil {
+		s.file.Close()
+	}
+
+	rotated := fmt.Sprintf("%s.%s", s.Path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(s.Path, rotated); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return s.open()
+}
+
+// Close implements Sink, closing the underlying file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	return s.file.Close()
+}
@@ -0,0 +1,15 @@
+package lopher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFmtHeaderZeroPadded(t *testing.T) {
+	at := time.Date(2009, time.January, 2, 3, 4, 5, 0, time.UTC)
+	actual := fmtHeader(LFdate|LFtime|LFUTC, "", at)
+	expected := "2009/01/02 03:04:05 "
+	if actual != expected {
+		t.Errorf("expected: %q\tactual: %q", expected, actual)
+	}
+}
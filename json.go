@@ -0,0 +1,58 @@
+package lopher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// encodeJSON renders a single log entry as a line of JSON: ts, level, caller
+// (when present) and msg always come first in that order, followed by any
+// inherited fields. Unlike the text formatter, which mashes newlines into
+// spaces so each entry fits on one line, encodeJSON only trims leading and
+// trailing whitespace from msg and passes the rest through encoding/json, so
+// inner newlines (and everything else) get escaped properly rather than
+// collapsed.
+func encodeJSON(t time.Time, level, caller string, fields []interface{}, msg string) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	writeJSONField(buf, "ts", t.Format(time.RFC3339Nano), true)
+	writeJSONField(buf, "level", level, false)
+	if caller != "" {
+		writeJSONField(buf, "caller", caller, false)
+	}
+	writeJSONField(buf, "msg", strings.TrimSpace(msg), false)
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			key = fmt.Sprint(fields[i])
+		}
+		writeJSONField(buf, key, fields[i+1], false)
+	}
+
+	buf.WriteByte('}')
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// writeJSONField appends a "key":value pair to buf, encoding both with
+// encoding/json so arbitrary values are escaped correctly.
+func writeJSONField(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+}
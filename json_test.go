@@ -0,0 +1,23 @@
+package lopher
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestNewJSONPreservesNewlines(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := NewJSON(b, false, LFNone)
+	l.Info("line1\nline2")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("output wasn't valid JSON: %v\n%s", err, b.String())
+	}
+
+	expected := "line1\nline2"
+	if decoded["msg"] != expected {
+		t.Errorf("expected msg %q, got %q", expected, decoded["msg"])
+	}
+}
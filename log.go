@@ -43,6 +43,24 @@ type Logger interface {
 
 	// SetDebug sets debug mode
 	SetDebug(bool)
+
+	// With returns a child Logger that carries keyvals on every entry it logs
+	// in addition to whatever its parent already carries. keyvals is a list of
+	// alternating key, value pairs, e.g. With("component", "api", "reqID", 42).
+	With(keyvals ...interface{}) Logger
+
+	// AddSink registers a Sink to receive every entry logged from now on.
+	// Once at least one Sink is registered, the Logger stops writing to its
+	// own Writer/format and fans entries out to sinks instead.
+	AddSink(Sink)
+
+	// RemoveSink stops feeding s and closes it.
+	RemoveSink(Sink)
+
+	// Subscribe registers f to be called with every entry logged from now
+	// on, independent of whatever Sinks are configured. The returned cancel
+	// func unregisters f.
+	Subscribe(f func(Entry)) (cancel func())
 }
 
 // LogFlags are a set of flags which define the prefix for each log entry
@@ -69,21 +87,88 @@ const (
 	LFNone         = 0
 )
 
-// New creates a new Logger
+// New creates a new Logger that writes human-readable entries
 // Loggers are immutable
 func New(out io.Writer, debug bool, flags LogFlags) Logger {
-	return &Log{sync.Mutex{}, out, debug, flags}
+	return &Log{Writer: out, DebugMode: debug, Flags: flags, format: formatText}
 }
 
+// NewJSON creates a new Logger that writes each entry as a single line of JSON,
+// e.g. {"ts":"...","level":"INFO","caller":"file.go:23","msg":"..."}, suitable
+// for feeding into a log-aggregation pipeline (ELK, Loki, ...) without
+// downstream regex parsing.
+func NewJSON(out io.Writer, debug bool, flags LogFlags) Logger {
+	return &Log{Writer: out, DebugMode: debug, Flags: flags, format: formatJSON}
+}
+
+// logFormat selects how a Log renders its entries
+type logFormat int
+
+const (
+	// formatText renders entries as the classic "header[LEVEL] message" line
+	formatText logFormat = iota
+	// formatJSON renders entries as a single line of JSON
+	formatJSON
+)
+
 // Log is a simple implementation of Logger
 type Log struct {
-	sync.Mutex
+	sync.RWMutex
 	// Output is the writer to send logs to
 	io.Writer
 	// Debug when true will enable the DEBUG logging level
 	DebugMode bool
 	// Flags are a set of flags to use for setting up log time stamps
 	Flags LogFlags
+	// format selects the on-the-wire rendering of each entry
+	format logFormat
+	// fields are key/value pairs inherited from With, attached to every entry
+	fields []interface{}
+	// sinks, once non-empty, take over from Writer/format entirely
+	sinks []*registeredSink
+	// recorder backs Subscribe; lazily created on first use
+	recorder *Recorder
+}
+
+// Subscribe registers f to be called with every entry logged from now on.
+// It works regardless of whether any Sink is configured, which makes it
+// handy in tests that want to assert on log output without swapping
+// SetOutput.
+func (l *Log) Subscribe(f func(Entry)) (cancel func()) {
+	l.Lock()
+	if l.recorder == nil {
+		l.recorder = NewRecorder(defaultRecorderSize)
+	}
+	r := l.recorder
+	l.Unlock()
+
+	return r.Subscribe(f)
+}
+
+// With returns a child Logger that attaches keyvals, plus anything inherited
+// from l, to every entry it logs. The parent Logger is unaffected. The child
+// starts with a copy of l's sinks and l's recorder as they are at this call,
+// so entries logged through the child reach whatever AddSink/Subscribe was
+// configured on l before it; sinks added to or removed from l afterwards,
+// and a recorder created by a first Subscribe on l afterwards, do not apply
+// to the child.
+func (l *Log) With(keyvals ...interface{}) Logger {
+	l.Lock()
+	defer l.Unlock()
+
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals))
+	fields = append(fields, l.fields...)
+	fields = append(fields, keyvals...)
+
+	return &Log{
+		Writer:    l.Writer,
+		DebugMode: l.DebugMode,
+		Flags:     l.Flags,
+		format:    l.format,
+		fields:    fields,
+		sinks:     append([]*registeredSink(nil), l.sinks...),
+		recorder:  l.recorder,
+	}
 }
 
 // SetFlags sets the log flags used by the Logger
@@ -135,29 +220,124 @@ func (l *Log) Debugf(fmtStr string, v ...interface{}) {
 	l.print("DEBUG", fmt.Sprintf(fmtStr, v...))
 }
 
-func (l *Log) print(level string, v ...interface{}) error {
-	l.Lock()
-	defer l.Unlock()
+// bufPool recycles the *bytes.Buffer print formats each entry into, so the
+// common case of writing a log line doesn't allocate one per call.
+var bufPool = sync.Pool{New: func() interface{} { return &bytes.Buffer{} }}
 
+func (l *Log) print(level string, v ...interface{}) error {
+	// caller is computed here, at a fixed stack depth, so it must be skipped
+	// entirely when a wrapper (e.g. Sampler) already knows the real call
+	// site; see infoAt/debugAt below.
 	caller := ""
-	if l.Flags&(LFshortfile|LFlongfile) != 0 {
-		l.Unlock()
+	if l.callerWanted() {
+		// runtime.Caller is already goroutine-safe, so this runs fully unlocked.
 		// # of calls back up the call stack
 		_, file, line, ok := runtime.Caller(2)
 		if !ok {
 			file = "???"
 			line = 0
 		}
-		l.Lock()
 
 		caller = fmt.Sprintf("%s:%d", file, line)
 	}
 
-	header := fmtHeader(l.Flags, caller, time.Now())
-	// wipe out all of the new lines for better structured logging
-	args := strings.Replace(fmt.Sprint(v...), "\n", " ", -1)
-	_, err := fmt.Fprintf(l, "%s[%s] %+v\n", header, level, strings.TrimSpace(args))
-	return fmt.Errorf("Could not write to output: %+v", err)
+	return l.emit(level, caller, fmt.Sprint(v...))
+}
+
+// callerWanted reports whether Flags asks for caller info, under a read lock.
+func (l *Log) callerWanted() bool {
+	l.RLock()
+	defer l.RUnlock()
+	return l.Flags&(LFshortfile|LFlongfile) != 0
+}
+
+// infoAt implements callerAt: it logs at info level using a caller already
+// computed by the caller (a Sampler), instead of deriving one itself.
+func (l *Log) infoAt(caller string, v ...interface{}) {
+	l.emit("INFO", caller, fmt.Sprint(v...))
+}
+
+// infofAt implements callerAt for the formatted-string variant.
+func (l *Log) infofAt(caller, fmtStr string, v ...interface{}) {
+	l.emit("INFO", caller, fmt.Sprintf(fmtStr, v...))
+}
+
+// debugAt implements callerAt: it logs at debug level using a caller already
+// computed by the caller (a Sampler), instead of deriving one itself.
+func (l *Log) debugAt(caller string, v ...interface{}) {
+	if !l.DebugMode {
+		return
+	}
+	l.emit("DEBUG", caller, fmt.Sprint(v...))
+}
+
+// debugfAt implements callerAt for the formatted-string variant.
+func (l *Log) debugfAt(caller, fmtStr string, v ...interface{}) {
+	if !l.DebugMode {
+		return
+	}
+	l.emit("DEBUG", caller, fmt.Sprintf(fmtStr, v...))
+}
+
+// emit snapshots the rest of l's state and writes one rendered entry for
+// (level, caller, msg) to the recorder, sinks and/or Writer.
+func (l *Log) emit(level, caller, msg string) error {
+	// Snapshot everything emit needs under a read lock so concurrent
+	// SetOutput/SetFlags calls can't interleave with, or be torn by, the
+	// unlocked work below.
+	l.RLock()
+	flags := l.Flags
+	format := l.format
+	fields := l.fields
+	sinks := l.sinks
+	recorder := l.recorder
+	l.RUnlock()
+
+	// A caller handed down by a Sampler is dropped when no caller flag is
+	// set, so entries carry one exactly when print would have looked it up.
+	if flags&(LFshortfile|LFlongfile) == 0 {
+		caller = ""
+	}
+
+	now := time.Now()
+	// Keep the raw message on Entry: the JSON encoder escapes newlines
+	// properly via json.Marshal, so only the text-rendering path below
+	// needs to collapse them into spaces.
+	entry := Entry{Time: now, Level: level, Caller: caller, Message: msg, Fields: fields}
+
+	if recorder != nil {
+		recorder.Accept(entry)
+	}
+
+	if len(sinks) > 0 {
+		dispatchSinks(sinks, entry)
+		return nil
+	}
+
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufPool.Put(buf)
+
+	if format == formatJSON {
+		jsonCaller := caller
+		if flags&LFshortfile != 0 {
+			jsonCaller = caller[strings.LastIndex(caller, "/")+1:]
+		}
+		buf.Write(encodeJSON(now, level, jsonCaller, fields, msg))
+	} else {
+		header := fmtHeader(flags, caller, now)
+		line := strings.TrimSpace(strings.Replace(msg, "\n", " ", -1))
+		fmt.Fprintf(buf, "%s[%s] %+v\n", header, level, line)
+	}
+
+	l.Lock()
+	_, err := l.Write(buf.Bytes())
+	l.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("Could not write to output: %+v", err)
+	}
+	return nil
 }
 
 func fmtHeader(flags LogFlags, file string, t time.Time) string {
@@ -172,11 +352,11 @@ func fmtHeader(flags LogFlags, file string, t time.Time) string {
 
 	if flags&(LFdate|LFtime|LFmicroseconds) != 0 {
 		y, m, d := t.Date()
-		buf.WriteString(fmt.Sprintf("%d/%d/%d ", y, m, d))
+		buf.WriteString(fmt.Sprintf("%04d/%02d/%02d ", y, m, d))
 
 		if flags&(LFtime) != 0 {
 			h, min, s := t.Clock()
-			buf.WriteString(fmt.Sprintf("%d:%d:%0d", h, min, s))
+			buf.WriteString(fmt.Sprintf("%02d:%02d:%02d", h, min, s))
 			if flags&(LFmicroseconds) != 0 {
 				buf.WriteString(fmt.Sprintf(".%d", t.Nanosecond()/1e3))
 			}
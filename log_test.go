@@ -8,38 +8,8 @@ import (
 	"time"
 )
 
-func TestPrefix(t *testing.T) {
-	l := New(nil, false, "", LFNone)
-	cases := map[string]struct {
-		subject  func(input ...interface{})
-		input    string
-		expected string
-		prefix   string
-		devMode  bool
-	}{
-		"\"main:\" prefix Info":     {l.Info, "Hello World!", "[INFO] main:Hello World!\n", "main:", false},
-		"empty string prefix Info":  {l.Info, "Hello World!", "[INFO] Hello World!\n", "", false},
-		"\"main:\" prefix Debug":    {l.Debug, "Hello World!", "[DEBUG] main:Hello World!\n", "main:", true},
-		"empty string prefix Debug": {l.Debug, "Hello World!", "[DEBUG] Hello World!\n", "", true},
-	}
-
-	for tcn, tc := range cases {
-		t.Run(tcn, func(t *testing.T) {
-			b := &bytes.Buffer{}
-			l.SetDebug(tc.devMode)
-			l.SetOutput(b)
-			l.SetPrefix(tc.prefix)
-			tc.subject(tc.input)
-			actual := b.String()
-			if actual != tc.expected {
-				t.Errorf("%s FAILED\n\texpected: \"%s\"\tactual:   \"%s\"", tcn, tc.expected, actual)
-			}
-		})
-	}
-}
-
 func TestFmtFuncs(t *testing.T) {
-	l := New(nil, false, "", LFNone)
+	l := New(nil, false, LFNone)
 	cases := map[string]struct {
 		subject  func(string, ...interface{})
 		fmt      string
@@ -68,7 +38,7 @@ func TestFmtFuncs(t *testing.T) {
 }
 
 func TestBaseFuncs(t *testing.T) {
-	l := New(nil, false, "", LFNone)
+	l := New(nil, false, LFNone)
 	cases := map[string]struct {
 		subject  func(...interface{})
 		input    []interface{}
@@ -119,7 +89,7 @@ func Example_package() {
 }
 
 func Example() {
-	l := New(os.Stdout, false, "App: ", LFNone)
+	l := New(os.Stdout, false, LFNone)
 	started := time.Now()
 	l.Info("Started.")
 
@@ -133,7 +103,7 @@ func Example() {
 	l.Debugf("ran for %v seconds.", s)
 	l.Info("Exiting.")
 	// Output:
-	// [INFO] App: Started.
-	// [DEBUG] App: ran for 1 seconds.
-	// [INFO] App: Exiting.
+	// [INFO] Started.
+	// [DEBUG] ran for 1 seconds.
+	// [INFO] Exiting.
 }
@@ -0,0 +1,63 @@
+package lopher
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// NetSink streams entries to a TCP or UDP endpoint, e.g. a remote syslog
+// collector. A dropped connection is reopened lazily on the next Accept.
+type NetSink struct {
+	Network  string // "tcp" or "udp"
+	Addr     string
+	MinLevel string
+	Flags    LogFlags
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewNetSink creates a NetSink dialing network/addr on first use.
+func NewNetSink(network, addr, minLevel string, flags LogFlags) *NetSink {
+	return &NetSink{Network: network, Addr: addr, MinLevel: minLevel, Flags: flags}
+}
+
+// Accept implements Sink.
+func (s *NetSink) Accept(e Entry) {
+	if !levelAtLeast(e.Level, s.MinLevel) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := net.Dial(s.Network, s.Addr)
+		if err != nil {
+			return
+		}
+		s.conn = conn
+	}
+
+	header := fmtHeader(s.Flags, e.Caller, e.Time)
+	line := fmt.Sprintf("%s[%s] %s%s\n", header, e.Level, collapseMessage(e.Message), formatFields(e.Fields))
+	if _, err := s.conn.Write([]byte(line)); err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Close implements Sink, closing the underlying connection if one is open.
+func (s *NetSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -5,7 +5,7 @@ import (
 	"os"
 )
 
-var dLogger = New(os.Stderr, false, "", LFstdFlags)
+var dLogger = New(os.Stderr, false, LFstdFlags)
 
 // Info logs at info level
 func Info(v ...interface{}) {
@@ -41,3 +41,25 @@ func SetFlags(f LogFlags) {
 func SetDebug(debug bool) {
 	dLogger.SetDebug(debug)
 }
+
+// With returns a child Logger of the default logger that attaches keyvals to
+// every entry it logs
+func With(keyvals ...interface{}) Logger {
+	return dLogger.With(keyvals...)
+}
+
+// AddSink registers a Sink with the default logger
+func AddSink(s Sink) {
+	dLogger.AddSink(s)
+}
+
+// RemoveSink unregisters a Sink from the default logger
+func RemoveSink(s Sink) {
+	dLogger.RemoveSink(s)
+}
+
+// Subscribe registers f to be called with every entry logged by the default
+// logger from now on. The returned cancel func unregisters f.
+func Subscribe(f func(Entry)) (cancel func()) {
+	return dLogger.Subscribe(f)
+}
@@ -0,0 +1,84 @@
+package lopher
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultRecorderSize is how many entries Log.Subscribe keeps around for
+// Since backfill when it lazily creates a Recorder.
+const defaultRecorderSize = 1000
+
+// Recorder is an in-memory ring buffer of the last N entries logged, plus
+// live subscriptions for new ones. It's inspired by syncthing's logger:
+// an application can expose a "/debug/logs" HTTP endpoint or a TUI panel
+// backed by a Recorder instead of tailing files, and tests can assert on
+// log output via Subscribe instead of swapping SetOutput.
+type Recorder struct {
+	mu      sync.Mutex
+	size    int
+	entries []Entry
+	subs    map[int]func(Entry)
+	nextID  int
+}
+
+// NewRecorder creates a Recorder that keeps the last size entries.
+func NewRecorder(size int) *Recorder {
+	return &Recorder{size: size, subs: make(map[int]func(Entry))}
+}
+
+// Accept appends e to the ring buffer and notifies current subscribers.
+func (r *Recorder) Accept(e Entry) {
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	if len(r.entries) > r.size {
+		r.entries = r.entries[len(r.entries)-r.size:]
+	}
+
+	subs := make([]func(Entry), 0, len(r.subs))
+	for _, f := range r.subs {
+		subs = append(subs, f)
+	}
+	r.mu.Unlock()
+
+	for _, f := range subs {
+		f(e)
+	}
+}
+
+// Close implements Sink. Recorder holds nothing that needs releasing.
+func (r *Recorder) Close() error { return nil }
+
+// Since returns the buffered entries logged at or after t, oldest first, so
+// a late subscriber can backfill before following live updates.
+func (r *Recorder) Since(t time.Time) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		if !e.Time.Before(t) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Subscribe registers f to be called with every entry recorded from now on.
+// The returned cancel func unregisters f; calling it more than once is a no-op.
+func (r *Recorder) Subscribe(f func(Entry)) (cancel func()) {
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.subs[id] = f
+	r.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.subs, id)
+			r.mu.Unlock()
+		})
+	}
+}
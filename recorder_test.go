@@ -0,0 +1,39 @@
+package lopher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorderRingBuffer(t *testing.T) {
+	r := NewRecorder(2)
+	r.Accept(Entry{Message: "one"})
+	r.Accept(Entry{Message: "two"})
+	r.Accept(Entry{Message: "three"})
+
+	entries := r.Since(time.Time{})
+	if len(entries) != 2 {
+		t.Fatalf("expected ring buffer to hold 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Message != "two" || entries[1].Message != "three" {
+		t.Errorf("expected [two three], got [%s %s]", entries[0].Message, entries[1].Message)
+	}
+}
+
+func TestRecorderSubscribe(t *testing.T) {
+	r := NewRecorder(10)
+
+	var got []string
+	cancel := r.Subscribe(func(e Entry) {
+		got = append(got, e.Message)
+	})
+
+	r.Accept(Entry{Message: "hello"})
+	cancel()
+	r.Accept(Entry{Message: "ignored"})
+
+	if len(got) != 1 || got[0] != "hello" {
+		t.Errorf("expected subscriber to see only [hello], got %v", got)
+	}
+}
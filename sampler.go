@@ -0,0 +1,277 @@
+package lopher
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// SampleStrategy selects how a Sampler decides which entries to drop.
+type SampleStrategy int
+
+const (
+	// SampleTokenBucket rate-limits each key to Rate entries/sec, allowing
+	// bursts of up to Burst.
+	SampleTokenBucket SampleStrategy = iota
+	// SampleTick logs the first First occurrences of a key per Tick window,
+	// then only every Everyth occurrence after that.
+	SampleTick
+)
+
+// SampleOpts configures a Sampler.
+type SampleOpts struct {
+	// Strategy selects the sampling algorithm.
+	Strategy SampleStrategy
+
+	// Rate and Burst apply to SampleTokenBucket: Rate is entries/sec
+	// refilled per key, Burst is the bucket's capacity.
+	Rate  float64
+	Burst int
+
+	// First, Every and Tick apply to SampleTick: log the first First
+	// occurrences of a key per Tick window, then every Everyth occurrence
+	// after that. Tick defaults to one second.
+	First int
+	Every int
+	Tick  time.Duration
+
+	// SuppressInterval is how often a dropped key gets a synthetic
+	// "suppressed N similar entries" message; it defaults to 10s.
+	SuppressInterval time.Duration
+}
+
+// sampleState tracks one sampled key (a format string, or a caller file:line).
+type sampleState struct {
+	tokens float64
+	last   time.Time
+
+	windowStart time.Time
+	count       int
+
+	suppressed      int
+	lastSuppressLog time.Time
+}
+
+// Sampler wraps a Logger and drops or aggregates repeated messages so a
+// tight loop calling Debug can't turn logging into a bottleneck.
+type Sampler struct {
+	next Logger
+	opts SampleOpts
+
+	mu      sync.Mutex
+	buckets map[string]*sampleState
+}
+
+// NewSampled wraps l so that repeated messages are rate-limited or sampled
+// per opts.Strategy, instead of every call paying the cost of formatting
+// and writing.
+func NewSampled(l Logger, opts SampleOpts) Logger {
+	if opts.Tick <= 0 {
+		opts.Tick = time.Second
+	}
+	if opts.SuppressInterval <= 0 {
+		opts.SuppressInterval = 10 * time.Second
+	}
+
+	return &Sampler{next: l, opts: opts, buckets: make(map[string]*sampleState)}
+}
+
+// callerAt is implemented by Loggers that can render an entry at a caller
+// site supplied by the caller, instead of deriving one via runtime.Caller.
+// Sampler adds a stack frame between the real caller and the wrapped
+// Logger, so it computes the real site itself (it already needs to, to key
+// Info/Debug) and hands it down through this interface rather than letting
+// the wrapped Logger's own runtime.Caller see the Sampler's frame.
+type callerAt interface {
+	infoAt(caller string, v ...interface{})
+	infofAt(caller, fmtStr string, v ...interface{})
+	debugAt(caller string, v ...interface{})
+	debugfAt(caller, fmtStr string, v ...interface{})
+}
+
+// Info implements Logger, keyed by the caller's file:line.
+func (s *Sampler) Info(v ...interface{}) {
+	s.infoAt(callerKey(), v...)
+}
+
+// Infof implements Logger, keyed by the format string itself.
+func (s *Sampler) Infof(fmtStr string, v ...interface{}) {
+	s.infofAt(callerKey(), fmtStr, v...)
+}
+
+// Debug implements Logger, keyed by the caller's file:line.
+func (s *Sampler) Debug(v ...interface{}) {
+	s.debugAt(callerKey(), v...)
+}
+
+// Debugf implements Logger, keyed by the format string itself.
+func (s *Sampler) Debugf(fmtStr string, v ...interface{}) {
+	s.debugfAt(callerKey(), fmtStr, v...)
+}
+
+// infoAt implements callerAt, forwarding caller on to s.next so a chain of
+// Samplers (or a Sampler wrapping a *Log) all attribute the entry to the
+// original call site instead of each other's frames.
+func (s *Sampler) infoAt(caller string, v ...interface{}) {
+	if !s.allow("INFO|" + caller) {
+		return
+	}
+	if cl, ok := s.next.(callerAt); ok {
+		cl.infoAt(caller, v...)
+		return
+	}
+	s.next.Info(v...)
+}
+
+// infofAt implements callerAt for the formatted-string variant.
+func (s *Sampler) infofAt(caller, fmtStr string, v ...interface{}) {
+	if !s.allow("INFO|" + fmtStr) {
+		return
+	}
+	if cl, ok := s.next.(callerAt); ok {
+		cl.infofAt(caller, fmtStr, v...)
+		return
+	}
+	s.next.Infof(fmtStr, v...)
+}
+
+// debugAt implements callerAt, forwarding caller on to s.next so a chain of
+// Samplers (or a Sampler wrapping a *Log) all attribute the entry to the
+// original call site instead of each other's frames.
+func (s *Sampler) debugAt(caller string, v ...interface{}) {
+	if !s.allow("DEBUG|" + caller) {
+		return
+	}
+	if cl, ok := s.next.(callerAt); ok {
+		cl.debugAt(caller, v...)
+		return
+	}
+	s.next.Debug(v...)
+}
+
+// debugfAt implements callerAt for the formatted-string variant.
+func (s *Sampler) debugfAt(caller, fmtStr string, v ...interface{}) {
+	if !s.allow("DEBUG|" + fmtStr) {
+		return
+	}
+	if cl, ok := s.next.(callerAt); ok {
+		cl.debugfAt(caller, fmtStr, v...)
+		return
+	}
+	s.next.Debugf(fmtStr, v...)
+}
+
+// SetFlags delegates to the wrapped Logger.
+func (s *Sampler) SetFlags(f LogFlags) { s.next.SetFlags(f) }
+
+// SetOutput delegates to the wrapped Logger.
+func (s *Sampler) SetOutput(w io.Writer) { s.next.SetOutput(w) }
+
+// SetDebug delegates to the wrapped Logger.
+func (s *Sampler) SetDebug(v bool) { s.next.SetDebug(v) }
+
+// With returns a Sampler, with its own independent sampling state, wrapping
+// the child Logger returned by the wrapped Logger's With.
+func (s *Sampler) With(keyvals ...interface{}) Logger {
+	return NewSampled(s.next.With(keyvals...), s.opts)
+}
+
+// AddSink delegates to the wrapped Logger.
+func (s *Sampler) AddSink(sink Sink) { s.next.AddSink(sink) }
+
+// RemoveSink delegates to the wrapped Logger.
+func (s *Sampler) RemoveSink(sink Sink) { s.next.RemoveSink(sink) }
+
+// Subscribe delegates to the wrapped Logger.
+func (s *Sampler) Subscribe(f func(Entry)) (cancel func()) { return s.next.Subscribe(f) }
+
+// allow reports whether the entry for key should be logged, advancing key's
+// sampling state and, once per SuppressInterval, logging how many entries
+// for key were dropped since the last time it said so.
+func (s *Sampler) allow(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.buckets[key]
+	if !ok {
+		st = &sampleState{}
+		s.buckets[key] = st
+	}
+
+	var ok2 bool
+	if s.opts.Strategy == SampleTick {
+		ok2 = s.allowTick(st)
+	} else {
+		ok2 = s.allowTokenBucket(st)
+	}
+
+	if !ok2 {
+		st.suppressed++
+		s.maybeLogSuppressed(st)
+	}
+
+	return ok2
+}
+
+func (s *Sampler) allowTokenBucket(st *sampleState) bool {
+	now := time.Now()
+	if st.last.IsZero() {
+		st.tokens = float64(s.opts.Burst)
+		st.last = now
+	} else {
+		st.tokens += now.Sub(st.last).Seconds() * s.opts.Rate
+		if st.tokens > float64(s.opts.Burst) {
+			st.tokens = float64(s.opts.Burst)
+		}
+		st.last = now
+	}
+
+	if st.tokens < 1 {
+		return false
+	}
+
+	st.tokens--
+	return true
+}
+
+func (s *Sampler) allowTick(st *sampleState) bool {
+	now := time.Now()
+	if st.windowStart.IsZero() || now.Sub(st.windowStart) >= s.opts.Tick {
+		st.windowStart = now
+		st.count = 0
+	}
+
+	st.count++
+	if st.count <= s.opts.First {
+		return true
+	}
+
+	over := st.count - s.opts.First
+	return s.opts.Every > 0 && over%s.opts.Every == 0
+}
+
+// maybeLogSuppressed must be called with s.mu held.
+func (s *Sampler) maybeLogSuppressed(st *sampleState) {
+	now := time.Now()
+	if !st.lastSuppressLog.IsZero() && now.Sub(st.lastSuppressLog) < s.opts.SuppressInterval {
+		return
+	}
+
+	st.lastSuppressLog = now
+	n := st.suppressed
+	st.suppressed = 0
+	s.next.Infof("suppressed %d similar entries", n)
+}
+
+// callerKey returns "/path/to/file.go:23" for whoever called the Sampler method that
+// called callerKey, used to key Info/Debug calls (which have no format
+// string to key on).
+func callerKey() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return "???"
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,58 @@
+package lopher
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplerTokenBucketBurst(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := New(b, false, LFNone)
+	s := NewSampled(l, SampleOpts{Strategy: SampleTokenBucket, Rate: 1, Burst: 2})
+
+	for i := 0; i < 3; i++ {
+		s.Info("hello")
+	}
+
+	lines := strings.Count(b.String(), "hello")
+	if lines != 2 {
+		t.Errorf("expected burst of 2 to get through, got %d", lines)
+	}
+}
+
+func TestSamplerTickFirstNThenEveryM(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := New(b, false, LFNone)
+	s := NewSampled(l, SampleOpts{Strategy: SampleTick, First: 2, Every: 3, Tick: time.Minute})
+
+	for i := 0; i < 8; i++ {
+		s.Infof("tick %d", i)
+	}
+
+	// first 2 (i=0,1) pass, then every 3rd occurrence after that: i=4, i=7
+	got := strings.Count(b.String(), "tick ")
+	if got != 4 {
+		t.Errorf("expected 4 entries to pass (2 first + every 3rd), got %d\n%s", got, b.String())
+	}
+}
+
+// TestSamplerPreservesCaller checks that a sampled entry is attributed to
+// the caller of Sampler.Info, not to the line inside sampler.go that
+// forwards the call to the wrapped Logger.
+func TestSamplerPreservesCaller(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := New(b, false, LFshortfile)
+	s := NewSampled(l, SampleOpts{Strategy: SampleTokenBucket, Rate: 1, Burst: 1})
+
+	s.Info("hello") // this call site is what should show up below
+
+	out := b.String()
+	if strings.Contains(out, "sampler.go") {
+		t.Errorf("caller attributed to sampler.go, want sampler_test.go:\n%s", out)
+	}
+	if !strings.Contains(out, "sampler_test.go:") {
+		t.Errorf("expected caller in sampler_test.go, got:\n%s", out)
+	}
+}
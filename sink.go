@@ -0,0 +1,149 @@
+package lopher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sinkBufferSize bounds how many entries a slow Sink can fall behind by
+// before new entries for it are dropped instead of stalling the caller.
+const sinkBufferSize = 256
+
+// Sink is a single log destination with its own minimum level and
+// rendering. Log fans every Entry out to every registered Sink; a Sink
+// that doesn't want the entry (because of its own level filter) simply
+// drops it in Accept.
+type Sink interface {
+	// Accept renders and writes e, or silently drops it.
+	Accept(Entry)
+	// Close flushes and releases any resources (files, sockets, goroutines)
+	// held by the sink.
+	Close() error
+}
+
+// registeredSink pairs a Sink with the bounded channel and worker goroutine
+// that feed it, so a slow sink can't stall whoever is calling Info/Debug.
+type registeredSink struct {
+	sink Sink
+	ch   chan Entry
+	done chan struct{}
+	// stopped is closed once run has returned, so RemoveSink can wait for
+	// any in-flight Accept to finish before closing the sink out from
+	// under it.
+	stopped chan struct{}
+}
+
+func newRegisteredSink(s Sink) *registeredSink {
+	rs := &registeredSink{
+		sink:    s,
+		ch:      make(chan Entry, sinkBufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go rs.run()
+	return rs
+}
+
+func (rs *registeredSink) run() {
+	defer close(rs.stopped)
+	for {
+		select {
+		case e := <-rs.ch:
+			rs.sink.Accept(e)
+		case <-rs.done:
+			return
+		}
+	}
+}
+
+// AddSink registers s to receive every entry logged from now on.
+func (l *Log) AddSink(s Sink) {
+	l.Lock()
+	defer l.Unlock()
+	l.sinks = append(l.sinks, newRegisteredSink(s))
+}
+
+// RemoveSink stops feeding s and closes it. It's a no-op if s was never
+// registered via AddSink.
+func (l *Log) RemoveSink(s Sink) {
+	l.Lock()
+	var rs *registeredSink
+	for i, candidate := range l.sinks {
+		if candidate.sink == s {
+			rs = candidate
+			l.sinks = append(l.sinks[:i], l.sinks[i+1:]...)
+			break
+		}
+	}
+	l.Unlock()
+
+	if rs == nil {
+		return
+	}
+
+	// Stop feeding rs and wait for its worker to actually exit before
+	// closing the sink, so Close can't run concurrently with an in-flight
+	// Accept on an already-buffered entry.
+	close(rs.done)
+	<-rs.stopped
+	rs.sink.Close()
+}
+
+// dispatchSinks fans e out to every sink in sinks without blocking; a sink
+// that's falling behind has the entry dropped for it rather than stalling
+// the caller. sinks is expected to be a snapshot taken under at least a
+// read lock, not the live Log.sinks slice.
+func dispatchSinks(sinks []*registeredSink, e Entry) {
+	for _, rs := range sinks {
+		select {
+		case rs.ch <- e:
+		default:
+		}
+	}
+}
+
+// levelAtLeast reports whether level passes a sink's MinLevel filter. An
+// empty (zero-value) min accepts every level.
+func levelAtLeast(level, min string) bool {
+	return levelRank(level) >= levelRank(min)
+}
+
+func levelRank(level string) int {
+	if level == "INFO" {
+		return 1
+	}
+	return 0
+}
+
+// collapseMessage mashes a (possibly multi-line) Entry.Message onto one
+// line, the way Log's own text formatter does, for text-oriented Sinks
+// (Console, File, Syslog, Net) whose output is one line per entry. The JSON
+// format doesn't use this: it lets encoding/json escape newlines properly
+// instead of collapsing them.
+func collapseMessage(msg string) string {
+	return strings.TrimSpace(strings.Replace(msg, "\n", " ", -1))
+}
+
+// formatFields renders e.Fields as trailing " key=value" pairs, the way a
+// text-oriented Sink appends them after its message, so the structured
+// fields a caller attached via Log.With actually show up in its output.
+func formatFields(fields []interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			key = fmt.Sprint(fields[i])
+		}
+
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		fmt.Fprintf(&b, "%v", fields[i+1])
+	}
+
+	return b.String()
+}
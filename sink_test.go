@@ -0,0 +1,48 @@
+package lopher
+
+import "testing"
+
+func TestLevelAtLeast(t *testing.T) {
+	cases := map[string]struct {
+		level    string
+		min      string
+		expected bool
+	}{
+		"debug entry, no min":   {"DEBUG", "", true},
+		"debug entry, min info": {"DEBUG", "INFO", false},
+		"info entry, min debug": {"INFO", "DEBUG", true},
+		"info entry, min info":  {"INFO", "INFO", true},
+		"info entry, no min":    {"INFO", "", true},
+	}
+
+	for tcn, tc := range cases {
+		t.Run(tcn, func(t *testing.T) {
+			actual := levelAtLeast(tc.level, tc.min)
+			if actual != tc.expected {
+				t.Errorf("%s FAILED\n\texpected: %v\tactual: %v", tcn, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatFields(t *testing.T) {
+	cases := map[string]struct {
+		fields   []interface{}
+		expected string
+	}{
+		"no fields":      {nil, ""},
+		"one pair":       {[]interface{}{"component", "api"}, " component=api"},
+		"two pairs":      {[]interface{}{"component", "api", "reqID", 42}, " component=api reqID=42"},
+		"dangling key":   {[]interface{}{"component"}, ""},
+		"non-string key": {[]interface{}{7, "seven"}, " 7=seven"},
+	}
+
+	for tcn, tc := range cases {
+		t.Run(tcn, func(t *testing.T) {
+			actual := formatFields(tc.fields)
+			if actual != tc.expected {
+				t.Errorf("%s FAILED\n\texpected: %q\tactual: %q", tcn, tc.expected, actual)
+			}
+		})
+	}
+}
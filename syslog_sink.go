@@ -0,0 +1,40 @@
+package lopher
+
+import (
+	"fmt"
+	"io"
+)
+
+// SyslogSink writes entries prefixed with a systemd/syslog-style priority,
+// e.g. "<6>" for INFO or "<7>" for DEBUG, to Writer (typically a unix
+// socket, or stdout captured by a supervisor that understands the
+// convention).
+type SyslogSink struct {
+	Writer   io.Writer
+	MinLevel string
+}
+
+// NewSyslogSink creates a SyslogSink writing to w.
+func NewSyslogSink(w io.Writer, minLevel string) *SyslogSink {
+	return &SyslogSink{Writer: w, MinLevel: minLevel}
+}
+
+// Accept implements Sink.
+func (s *SyslogSink) Accept(e Entry) {
+	if !levelAtLeast(e.Level, s.MinLevel) {
+		return
+	}
+
+	fmt.Fprintf(s.Writer, "<%d>%s%s\n", syslogSeverity(e.Level), collapseMessage(e.Message), formatFields(e.Fields))
+}
+
+// Close implements Sink. SyslogSink holds nothing that needs releasing.
+func (s *SyslogSink) Close() error { return nil }
+
+// syslogSeverity maps a lopher level to an RFC5424 severity code.
+func syslogSeverity(level string) int {
+	if level == "DEBUG" {
+		return 7 // debug
+	}
+	return 6 // informational
+}